@@ -0,0 +1,137 @@
+package pgxscan
+
+import (
+	"context"
+	"errors"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// BatchQuery is a single statement queued as part of a batch, together with
+// the destination its result row(s) should be scanned into.
+type BatchQuery struct {
+	SQL  string
+	Args []interface{}
+	Dest interface{}
+}
+
+// BatchResults is returned by Client.SendBatch and exposes the queued
+// statements' results in the order they were queued. Each call consumes the
+// next result in the batch, mirroring pgx.BatchResults. Results honors the
+// active tx from context: if ctx carries a transaction, the batch is sent
+// over it instead of a fresh pool connection.
+type BatchResults interface {
+	// Get scans the next statement's single result row into dest, returning
+	// ErrRecordsNotFound if it produced no rows.
+	Get(dest interface{}) error
+	// Select scans all of the next statement's result rows into dest.
+	Select(dest interface{}) error
+	Exec() (pgconn.CommandTag, error)
+	QueryRow() pgx.Row
+	Close() error
+}
+
+var _ BatchResults = (*batchResults)(nil)
+
+type batchResults struct {
+	results pgx.BatchResults
+	scanApi *pgxscan.API
+}
+
+func (b *batchResults) Get(dest interface{}) error {
+	rows, err := b.results.Query()
+	if err != nil {
+		return err
+	}
+
+	if err := b.scanApi.ScanOne(dest, rows); err != nil {
+		if pgxscan.NotFound(err) {
+			return ErrRecordsNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (b *batchResults) Select(dest interface{}) error {
+	rows, err := b.results.Query()
+	if err != nil {
+		return err
+	}
+
+	return b.scanApi.ScanAll(dest, rows)
+}
+
+func (b *batchResults) Exec() (pgconn.CommandTag, error) {
+	return b.results.Exec()
+}
+
+func (b *batchResults) QueryRow() pgx.Row {
+	return b.results.QueryRow()
+}
+
+func (b *batchResults) Close() error {
+	return b.results.Close()
+}
+
+// SendBatch queues batch in a single network round-trip and returns its
+// results for the caller to consume in queue order.
+func (c *client) SendBatch(ctx context.Context, batch *pgx.Batch) BatchResults {
+	var results pgx.BatchResults
+	if tx := txFromContext(ctx); tx != nil {
+		results = tx.SendBatch(ctx, batch)
+	} else {
+		results = c.dbPool.SendBatch(ctx, batch)
+	}
+
+	return &batchResults{results: results, scanApi: c.scanApi}
+}
+
+// BatchGet runs queries as a single batch and scans each statement's single
+// result row into the matching BatchQuery.Dest.
+func (c *client) BatchGet(ctx context.Context, queries []BatchQuery) error {
+	results := c.SendBatch(ctx, batchOf(queries))
+
+	var err error
+	for _, q := range queries {
+		if err = results.Get(q.Dest); err != nil {
+			break
+		}
+	}
+
+	if closeErr := results.Close(); closeErr != nil {
+		err = errors.Join(err, closeErr)
+	}
+
+	return err
+}
+
+// BatchSelect runs queries as a single batch and scans each statement's
+// result rows into the matching BatchQuery.Dest.
+func (c *client) BatchSelect(ctx context.Context, queries []BatchQuery) error {
+	results := c.SendBatch(ctx, batchOf(queries))
+
+	var err error
+	for _, q := range queries {
+		if err = results.Select(q.Dest); err != nil {
+			break
+		}
+	}
+
+	if closeErr := results.Close(); closeErr != nil {
+		err = errors.Join(err, closeErr)
+	}
+
+	return err
+}
+
+func batchOf(queries []BatchQuery) *pgx.Batch {
+	batch := &pgx.Batch{}
+	for _, q := range queries {
+		batch.Queue(q.SQL, q.Args...)
+	}
+	return batch
+}
@@ -0,0 +1,23 @@
+package pgxscan
+
+import "testing"
+
+func TestBatchOf_QueuesInOrder(t *testing.T) {
+	queries := []BatchQuery{
+		{SQL: "select 1"},
+		{SQL: "select 2", Args: []interface{}{1, 2}},
+		{SQL: "select 3"},
+	}
+
+	batch := batchOf(queries)
+	if got := batch.Len(); got != len(queries) {
+		t.Fatalf("batch.Len() = %d, want %d", got, len(queries))
+	}
+
+	items := batch.QueuedQueries
+	for i, q := range queries {
+		if items[i].SQL != q.SQL {
+			t.Errorf("item %d: SQL = %q, want %q (batch must preserve queue order)", i, items[i].SQL, q.SQL)
+		}
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/dvsnin/pgxscan/metrics"
 )
 
 var _ Client = (*client)(nil)
@@ -21,11 +23,21 @@ type Client interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	InTx(ctx context.Context, isoLevel pgx.TxIsoLevel, f func(ctx context.Context) error) error
+	InTxWithOptions(ctx context.Context, opts pgx.TxOptions, f func(ctx context.Context) error) error
+	InReadOnlySnapshotTx(ctx context.Context, f func(ctx context.Context) error) error
+	SendBatch(ctx context.Context, batch *pgx.Batch) BatchResults
+	BatchGet(ctx context.Context, queries []BatchQuery) error
+	BatchSelect(ctx context.Context, queries []BatchQuery) error
 	Ping(ctx context.Context) error
 	Close()
 }
 
-func New(ctx context.Context, config Config) (Client, error) {
+func New(ctx context.Context, config Config, opts ...ClientOption) (Client, error) {
+	var options clientOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	dsnPool := dbDSN(config)
 	pgxConfig, err := pgxpool.ParseConfig(dsnPool)
 	if err != nil {
@@ -38,6 +50,14 @@ func New(ctx context.Context, config Config) (Client, error) {
 		}
 	}
 
+	if config.TLSConfig != nil {
+		pgxConfig.ConnConfig.TLSConfig = config.TLSConfig
+	}
+
+	if len(options.tracers) > 0 {
+		pgxConfig.ConnConfig.Tracer = &pgxTracerAdapter{tracers: options.tracers}
+	}
+
 	pool, err := pgxpool.NewWithConfig(ctx, pgxConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
@@ -62,11 +82,26 @@ func New(ctx context.Context, config Config) (Client, error) {
 		return nil, fmt.Errorf("pgxscan.NewAPI: %w", err)
 	}
 
-	return &client{
+	c := &client{
 		dbPool:       pool,
 		queryTimeout: timeout,
 		scanApi:      pgxscanApi,
-	}, nil
+		tracers:      options.tracers,
+		retry:        config.Retry,
+	}
+
+	// Collectors are registered here, after every fallible step above has
+	// succeeded, so a failed New never leaves metrics registered with no
+	// client around to report on (registerer.MustRegister would also panic
+	// on a subsequent retry against the same registerer).
+	if options.metricsRegisterer != nil {
+		c.metrics = metrics.NewCollector(options.metricsRegisterer, options.metricsNamespace)
+		metricsCtx, cancel := context.WithCancel(context.Background())
+		c.stopMetrics = cancel
+		go c.metrics.Run(metricsCtx, pool, options.metricsScrapeInterval)
+	}
+
+	return c, nil
 }
 
 // Client for db
@@ -74,13 +109,36 @@ type client struct {
 	dbPool       *pgxpool.Pool
 	queryTimeout time.Duration
 	scanApi      *pgxscan.API
+
+	metrics     *metrics.Collector
+	stopMetrics context.CancelFunc
+
+	tracers []QueryTracer
+	retry   RetryPolicy
+}
+
+// recordMetrics reports the outcome of a query to the metrics collector, if
+// the client was created with WithMetrics. op is read from ctx, not the
+// (possibly timeout-wrapped) context passed to the underlying scanApi call.
+func (c *client) recordMetrics(ctx context.Context, method string, start time.Time, err error) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.ObserveQuery(method, opNameFromContext(ctx), time.Since(start), err)
 }
 
 func (c *client) Get(ctx context.Context, dest interface{}, sql string, args ...interface{}) error {
+	start := time.Now()
+	ctx = c.traceStart(ctx, "Get", sql, args)
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, c.queryTimeout)
 	defer cancel()
 
-	if err := c.getInTx(ctxWithTimeout, dest, sql, args...); err != nil {
+	err := withRetry(ctxWithTimeout, c.retry, func() error {
+		return c.getInTx(ctxWithTimeout, dest, sql, args...)
+	})
+	c.recordMetrics(ctx, "Get", start, err)
+	c.traceEnd(ctx, "Get", err, 0)
+	if err != nil {
 		if pgxscan.NotFound(err) {
 			return ErrRecordsNotFound
 		}
@@ -91,10 +149,17 @@ func (c *client) Get(ctx context.Context, dest interface{}, sql string, args ...
 }
 
 func (c *client) Select(ctx context.Context, dest interface{}, sql string, args ...interface{}) error {
+	start := time.Now()
+	ctx = c.traceStart(ctx, "Select", sql, args)
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, c.queryTimeout)
 	defer cancel()
 
-	if err := c.selectInTx(ctxWithTimeout, dest, sql, args...); err != nil {
+	err := withRetry(ctxWithTimeout, c.retry, func() error {
+		return c.selectInTx(ctxWithTimeout, dest, sql, args...)
+	})
+	c.recordMetrics(ctx, "Select", start, err)
+	c.traceEnd(ctx, "Select", err, 0)
+	if err != nil {
 		if pgxscan.NotFound(err) {
 			return ErrRecordsNotFound
 		}
@@ -105,34 +170,109 @@ func (c *client) Select(ctx context.Context, dest interface{}, sql string, args
 }
 
 func (c *client) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	start := time.Now()
+	ctx = c.traceStart(ctx, "Exec", sql, args)
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, c.queryTimeout)
 	defer cancel()
 
-	return c.execInTx(ctxWithTimeout, sql, args...)
+	var tag pgconn.CommandTag
+	err := withRetry(ctxWithTimeout, c.retry, func() error {
+		var execErr error
+		tag, execErr = c.execInTx(ctxWithTimeout, sql, args...)
+		return execErr
+	})
+	c.recordMetrics(ctx, "Exec", start, err)
+	c.traceEnd(ctx, "Exec", err, tag.RowsAffected())
+
+	return tag, err
 }
 
 func (c *client) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	start := time.Now()
+	ctx = c.traceStart(ctx, "Query", sql, args)
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, c.queryTimeout)
 	defer cancel()
 
-	return c.queryInTx(ctxWithTimeout, sql, args...)
+	rows, err := c.queryInTx(ctxWithTimeout, sql, args...)
+	c.recordMetrics(ctx, "Query", start, err)
+	c.traceEnd(ctx, "Query", err, 0)
+
+	return rows, err
 }
 
 func (c *client) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	start := time.Now()
+	ctx = c.traceStart(ctx, "QueryRow", sql, args)
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, c.queryTimeout)
 	defer cancel()
 
-	return c.queryRowsInTx(ctxWithTimeout, sql, args...)
+	row := c.queryRowsInTx(ctxWithTimeout, sql, args...)
+	c.recordMetrics(ctx, "QueryRow", start, nil)
+	c.traceEnd(ctx, "QueryRow", nil, 0)
+
+	return row
 }
 
 func (c *client) InTx(ctx context.Context, isoLevel pgx.TxIsoLevel, f func(ctx context.Context) error) error {
+	return c.InTxWithOptions(ctx, pgx.TxOptions{IsoLevel: isoLevel}, f)
+}
+
+// InReadOnlySnapshotTx runs f in a REPEATABLE READ READ ONLY DEFERRABLE
+// transaction, the canonical Postgres pattern for a consistent multi-statement
+// read snapshot that never blocks on write locks.
+//
+// If ctx already carries a transaction, InReadOnlySnapshotTx returns an error
+// without running f: it could only nest via a SAVEPOINT (see
+// InTxWithOptions), which cannot establish its own isolation level or access
+// mode, so it fails rather than silently running with whatever mode the
+// outer transaction happens to have.
+func (c *client) InReadOnlySnapshotTx(ctx context.Context, f func(ctx context.Context) error) error {
+	return c.InTxWithOptions(ctx, pgx.TxOptions{
+		IsoLevel:       pgx.RepeatableRead,
+		AccessMode:     pgx.ReadOnly,
+		DeferrableMode: pgx.Deferrable,
+	}, f)
+}
+
+// InTxWithOptions runs f inside a transaction opened with opts. If ctx
+// already carries a transaction, f instead runs inside a SAVEPOINT nested in
+// that transaction: a SAVEPOINT always inherits the outer transaction's
+// isolation level and access mode, so a non-zero opts can't be honored and
+// InTxWithOptions returns an error instead of silently ignoring it.
+func (c *client) InTxWithOptions(ctx context.Context, opts pgx.TxOptions, f func(ctx context.Context) error) error {
+	start := time.Now()
+	ctx = c.traceStart(ctx, "InTx", "", nil)
+
+	if parent := txFromContext(ctx); parent != nil {
+		if opts != (pgx.TxOptions{}) {
+			err := fmt.Errorf("pgxscan: cannot honor TxOptions %+v for a nested InTx: a SAVEPOINT always inherits the outer transaction's isolation level and access mode", opts)
+			c.recordMetrics(ctx, "InTx", start, err)
+			c.traceEnd(ctx, "InTx", err, 0)
+			return err
+		}
+		return c.inSavepointTx(ctx, parent, start, f)
+	}
+
+	// The whole transaction, not just a single statement, is the retried
+	// unit: on a serialization failure or deadlock the entire callback is
+	// re-run from scratch against a fresh connection and transaction.
+	err := withRetry(ctx, c.retry, func() error {
+		return c.runTx(ctx, opts, f)
+	})
+	c.recordMetrics(ctx, "InTx", start, err)
+	c.traceEnd(ctx, "InTx", err, 0)
+
+	return err
+}
+
+func (c *client) runTx(ctx context.Context, opts pgx.TxOptions, f func(ctx context.Context) error) error {
 	conn, err := c.dbPool.Acquire(ctx)
 	if err != nil {
 		return fmt.Errorf("acquiring connection: %w", err)
 	}
 	defer conn.Release()
 
-	tx, err := conn.BeginTx(ctx, pgx.TxOptions{IsoLevel: isoLevel})
+	tx, err := conn.BeginTx(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("starting transaction: %w", err)
 	}
@@ -154,11 +294,56 @@ func (c *client) InTx(ctx context.Context, isoLevel pgx.TxIsoLevel, f func(ctx c
 	return nil
 }
 
+// inSavepointTx runs f inside a SAVEPOINT nested within the pgx.Tx already
+// active on ctx, instead of acquiring a fresh connection and top-level
+// transaction. parent.Begin establishes the savepoint and returns a pgx.Tx
+// whose Commit/Rollback issue RELEASE SAVEPOINT / ROLLBACK TO SAVEPOINT, so
+// only this nesting level is unwound on failure.
+func (c *client) inSavepointTx(ctx context.Context, parent pgx.Tx, start time.Time, f func(ctx context.Context) error) error {
+	depth := txDepthFromContext(ctx) + 1
+
+	savepointTx, err := parent.Begin(ctx)
+	if err != nil {
+		err = fmt.Errorf("starting savepoint at depth %d: %w", depth, err)
+		c.recordMetrics(ctx, "InTx", start, err)
+		c.traceEnd(ctx, "InTx", err, 0)
+		return err
+	}
+
+	ctxWithTx := contextWithTxDepth(contextWithTx(ctx, savepointTx), depth)
+
+	if err := f(ctxWithTx); err != nil {
+		if err1 := savepointTx.Rollback(ctxWithTx); err1 != nil {
+			err = fmt.Errorf("rolling back to savepoint at depth %d: %v (original error: %w)", depth, err1, err)
+			c.recordMetrics(ctx, "InTx", start, err)
+			c.traceEnd(ctx, "InTx", err, 0)
+			return err
+		}
+		c.recordMetrics(ctx, "InTx", start, err)
+		c.traceEnd(ctx, "InTx", err, 0)
+		return err
+	}
+
+	if err := savepointTx.Commit(ctxWithTx); err != nil {
+		err = fmt.Errorf("releasing savepoint at depth %d: %w", depth, err)
+		c.recordMetrics(ctx, "InTx", start, err)
+		c.traceEnd(ctx, "InTx", err, 0)
+		return err
+	}
+
+	c.recordMetrics(ctx, "InTx", start, nil)
+	c.traceEnd(ctx, "InTx", nil, 0)
+	return nil
+}
+
 func (c *client) Ping(ctx context.Context) error {
 	return c.dbPool.Ping(ctx)
 }
 
 func (c *client) Close() {
+	if c.stopMetrics != nil {
+		c.stopMetrics()
+	}
 	c.dbPool.Close()
 }
 
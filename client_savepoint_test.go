@@ -0,0 +1,91 @@
+package pgxscan
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func TestInSavepointTx_CommitsAndTracksDepth(t *testing.T) {
+	c := &client{}
+	parent := &fakeTx{}
+
+	err := c.inSavepointTx(context.Background(), parent, time.Now(), func(ctx context.Context) error {
+		if depth := txDepthFromContext(ctx); depth != 1 {
+			t.Fatalf("expected depth 1, got %d", depth)
+		}
+		if tx := txFromContext(ctx); tx != parent.child {
+			t.Fatalf("expected ctx tx to be the savepoint tx, got %v", tx)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !parent.child.committed {
+		t.Fatal("expected the savepoint tx to be committed")
+	}
+	if parent.committed {
+		t.Fatal("parent tx must not be committed by a nested InTx")
+	}
+}
+
+func TestInSavepointTx_RollsBackOnlyItsOwnSavepoint(t *testing.T) {
+	c := &client{}
+	parent := &fakeTx{}
+	wantErr := errors.New("boom")
+
+	err := c.inSavepointTx(context.Background(), parent, time.Now(), func(ctx context.Context) error {
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected error to wrap %v, got %v", wantErr, err)
+	}
+	if !parent.child.rolledBack {
+		t.Fatal("expected the savepoint tx to be rolled back")
+	}
+	if parent.rolledBack {
+		t.Fatal("parent tx must not be rolled back by a failed nested savepoint")
+	}
+}
+
+func TestInSavepointTx_NestedDepthIncrements(t *testing.T) {
+	c := &client{}
+	parent := &fakeTx{}
+
+	err := c.inSavepointTx(context.Background(), parent, time.Now(), func(ctx context.Context) error {
+		return c.inSavepointTx(ctx, txFromContext(ctx), time.Now(), func(ctx context.Context) error {
+			if depth := txDepthFromContext(ctx); depth != 2 {
+				t.Fatalf("expected depth 2 for the second nesting level, got %d", depth)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestInTxWithOptions_NestsViaSavepointWhenTxAlreadyActive(t *testing.T) {
+	c := &client{}
+	parent := &fakeTx{}
+	ctx := contextWithTx(context.Background(), parent)
+
+	ran := false
+	err := c.InTxWithOptions(ctx, pgx.TxOptions{}, func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ran {
+		t.Fatal("expected the callback to run")
+	}
+	if !parent.child.committed {
+		t.Fatal("expected InTx to nest via a savepoint when ctx already carries a tx")
+	}
+}
@@ -8,7 +8,18 @@ import (
 
 type ctxKey struct{}
 
-var txCtxKey = &ctxKey{}
+// Each key has its own type: pointers to distinct zero-size values may
+// compare equal, so three &ctxKey{} keys would shadow one another.
+type (
+	txDepthCtxKeyType struct{}
+	opNameCtxKeyType  struct{}
+)
+
+var (
+	txCtxKey      = &ctxKey{}
+	txDepthCtxKey = txDepthCtxKeyType{}
+	opNameCtxKey  = opNameCtxKeyType{}
+)
 
 func contextWithTx(ctx context.Context, tx pgx.Tx) context.Context {
 	return context.WithValue(ctx, txCtxKey, tx)
@@ -18,3 +29,26 @@ func txFromContext(ctx context.Context) pgx.Tx {
 	v, _ := ctx.Value(txCtxKey).(pgx.Tx)
 	return v
 }
+
+// contextWithTxDepth records how many savepoints deep the transaction on ctx
+// is nested, so InTx can identify which savepoint a rollback unwinds.
+func contextWithTxDepth(ctx context.Context, depth int) context.Context {
+	return context.WithValue(ctx, txDepthCtxKey, depth)
+}
+
+func txDepthFromContext(ctx context.Context) int {
+	v, _ := ctx.Value(txDepthCtxKey).(int)
+	return v
+}
+
+// WithOpName tags ctx with an operation name used to label the Prometheus
+// metrics emitted by Client methods. It has no effect unless the client was
+// created with WithMetrics.
+func WithOpName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, opNameCtxKey, name)
+}
+
+func opNameFromContext(ctx context.Context) string {
+	v, _ := ctx.Value(opNameCtxKey).(string)
+	return v
+}
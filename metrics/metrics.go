@@ -0,0 +1,162 @@
+// Package metrics provides the Prometheus instrumentation used by
+// pgxscan.Client when it is created with pgxscan.WithMetrics.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const subsystem = "pgxscan"
+
+// Collector holds the Prometheus metrics describing pgxscan client activity:
+// per-method/op query latency and errors, plus a point-in-time view of the
+// underlying pgxpool.Pool.Stat().
+type Collector struct {
+	queryDuration *prometheus.HistogramVec
+	queryErrors   *prometheus.CounterVec
+
+	poolAcquireCount         prometheus.Gauge
+	poolAcquiredConns        prometheus.Gauge
+	poolIdleConns            prometheus.Gauge
+	poolConstructingConns    prometheus.Gauge
+	poolCanceledAcquireCount prometheus.Gauge
+	poolEmptyAcquireCount    prometheus.Gauge
+	poolMaxConns             prometheus.Gauge
+	poolTotalConns           prometheus.Gauge
+	poolNewConnsCount        prometheus.Gauge
+}
+
+// NewCollector builds and registers a Collector under the given namespace.
+// It panics if any of the metrics are already registered with registerer,
+// mirroring prometheus.MustRegister semantics used elsewhere for process
+// wide collectors.
+func NewCollector(registerer prometheus.Registerer, namespace string) *Collector {
+	c := &Collector{
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "query_duration_seconds",
+			Help:      "Duration of pgxscan Client queries in seconds, labeled by method and operation name.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "op"}),
+		queryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "query_errors_total",
+			Help:      "Total number of pgxscan Client query errors, labeled by method and operation name.",
+		}, []string{"method", "op"}),
+		poolAcquireCount: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "pool_acquire_count",
+			Help:      "Cumulative count of successful connection acquires from the pool.",
+		}),
+		poolAcquiredConns: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "pool_acquired_conns",
+			Help:      "Number of connections currently acquired from the pool.",
+		}),
+		poolIdleConns: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "pool_idle_conns",
+			Help:      "Number of idle connections in the pool.",
+		}),
+		poolConstructingConns: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "pool_constructing_conns",
+			Help:      "Number of connections currently being constructed.",
+		}),
+		poolCanceledAcquireCount: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "pool_canceled_acquire_count",
+			Help:      "Cumulative count of acquires canceled by a context.",
+		}),
+		poolEmptyAcquireCount: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "pool_empty_acquire_count",
+			Help:      "Cumulative count of acquires from an empty pool.",
+		}),
+		poolMaxConns: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "pool_max_conns",
+			Help:      "Maximum number of connections allowed in the pool.",
+		}),
+		poolTotalConns: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "pool_total_conns",
+			Help:      "Total number of connections currently in the pool.",
+		}),
+		poolNewConnsCount: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "pool_new_conns_count",
+			Help:      "Cumulative count of new connections opened.",
+		}),
+	}
+
+	registerer.MustRegister(
+		c.queryDuration,
+		c.queryErrors,
+		c.poolAcquireCount,
+		c.poolAcquiredConns,
+		c.poolIdleConns,
+		c.poolConstructingConns,
+		c.poolCanceledAcquireCount,
+		c.poolEmptyAcquireCount,
+		c.poolMaxConns,
+		c.poolTotalConns,
+		c.poolNewConnsCount,
+	)
+
+	return c
+}
+
+// ObserveQuery records the outcome of a single query, labeled by the Client
+// method that ran it (e.g. "Get", "Select") and the operation name carried
+// on the context, if any.
+func (c *Collector) ObserveQuery(method, op string, duration time.Duration, err error) {
+	c.queryDuration.WithLabelValues(method, op).Observe(duration.Seconds())
+	if err != nil {
+		c.queryErrors.WithLabelValues(method, op).Inc()
+	}
+}
+
+// CollectPoolStats snapshots a pgxpool.Stat into the pool gauges.
+func (c *Collector) CollectPoolStats(stat *pgxpool.Stat) {
+	c.poolAcquireCount.Set(float64(stat.AcquireCount()))
+	c.poolAcquiredConns.Set(float64(stat.AcquiredConns()))
+	c.poolIdleConns.Set(float64(stat.IdleConns()))
+	c.poolConstructingConns.Set(float64(stat.ConstructingConns()))
+	c.poolCanceledAcquireCount.Set(float64(stat.CanceledAcquireCount()))
+	c.poolEmptyAcquireCount.Set(float64(stat.EmptyAcquireCount()))
+	c.poolMaxConns.Set(float64(stat.MaxConns()))
+	c.poolTotalConns.Set(float64(stat.TotalConns()))
+	c.poolNewConnsCount.Set(float64(stat.NewConnsCount()))
+}
+
+// Run periodically scrapes pool.Stat() until ctx is canceled. Callers are
+// expected to run it in its own goroutine.
+func (c *Collector) Run(ctx context.Context, pool *pgxpool.Pool, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.CollectPoolStats(pool.Stat())
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package pgxscan
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// defaultPoolStatsScrapeInterval is how often pool.Stat() is scraped into
+// the Prometheus gauges when WithMetrics is used.
+const defaultPoolStatsScrapeInterval = 15 * time.Second
+
+// ClientOption configures optional behavior of the Client returned by New.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	metricsRegisterer     prometheus.Registerer
+	metricsNamespace      string
+	metricsScrapeInterval time.Duration
+	tracers               []QueryTracer
+}
+
+// WithMetrics enables a Prometheus observability subsystem on the client:
+// query latency and error counters labeled by method and operation name
+// (see WithOpName), plus a periodic scrape of the underlying pool's Stat().
+// Metrics are registered under the given namespace on registerer once New
+// has successfully established the connection pool, so a failed New never
+// leaves collectors registered with no client to report on.
+func WithMetrics(registerer prometheus.Registerer, namespace string) ClientOption {
+	return func(o *clientOptions) {
+		o.metricsRegisterer = registerer
+		o.metricsNamespace = namespace
+		if o.metricsScrapeInterval == 0 {
+			o.metricsScrapeInterval = defaultPoolStatsScrapeInterval
+		}
+	}
+}
+
+// WithTracers registers one or more QueryTracers on the client. Every Get,
+// Select, Exec, Query, QueryRow and InTx call and every lower-level pgx query
+// event is reported to each tracer, in the order given. Ping, Close and the
+// batch methods are not traced, and statements sent in a batch are not
+// reported as pgx query events. See the tracing subpackage for ready-made
+// slog and OpenTelemetry tracers.
+func WithTracers(tracers ...QueryTracer) ClientOption {
+	return func(o *clientOptions) {
+		o.tracers = append(o.tracers, tracers...)
+	}
+}
@@ -0,0 +1,69 @@
+package pgxscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// PoolSize is a pool connection count. Its UnmarshalJSON accepts either a
+// JSON number or a numeric string, so configs written against the old
+// string-typed PoolMinConnections/PoolMaxConnections fields continue to
+// decode unchanged with encoding/json. mapstructure-based decoding needs
+// PoolSizeDecodeHookFunc registered explicitly; see its doc comment.
+type PoolSize int32
+
+// UnmarshalJSON accepts both a JSON number (5) and a numeric string ("5"),
+// the latter for compatibility with configs predating the int32 switch.
+func (s *PoolSize) UnmarshalJSON(data []byte) error {
+	var n int32
+	if err := json.Unmarshal(data, &n); err == nil {
+		*s = PoolSize(n)
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return fmt.Errorf("pgxscan: PoolSize must be a number or a numeric string, got %s", data)
+	}
+	if str == "" {
+		*s = 0
+		return nil
+	}
+
+	n, err := strconv.ParseInt(str, 10, 32)
+	if err != nil {
+		return fmt.Errorf("pgxscan: PoolSize %q is not a valid number: %w", str, err)
+	}
+	*s = PoolSize(n)
+	return nil
+}
+
+// PoolSizeDecodeHookFunc returns a mapstructure.DecodeHookFuncType-shaped
+// hook (written without importing mapstructure, to avoid adding it as a
+// dependency of this package) that decodes a numeric string into a
+// PoolSize. mapstructure does not consult PoolSize.UnmarshalJSON, so callers
+// decoding Config with mapstructure (rather than encoding/json) must register
+// this hook themselves via mapstructure.DecodeHookFunc for string-typed
+// PoolMinConnections / PoolMaxConnections values to keep decoding.
+func PoolSizeDecodeHookFunc() func(reflect.Type, reflect.Type, interface{}) (interface{}, error) {
+	poolSizeType := reflect.TypeOf(PoolSize(0))
+
+	return func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if to != poolSizeType || from.Kind() != reflect.String {
+			return data, nil
+		}
+
+		str, _ := data.(string)
+		if str == "" {
+			return PoolSize(0), nil
+		}
+
+		n, err := strconv.ParseInt(str, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("pgxscan: PoolSize %q is not a valid number: %w", str, err)
+		}
+		return PoolSize(n), nil
+	}
+}
@@ -0,0 +1,87 @@
+package pgxscan
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPoolSize_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    PoolSize
+		wantErr bool
+	}{
+		{"json number", `5`, 5, false},
+		{"numeric string", `"5"`, 5, false},
+		{"empty string defaults to zero", `""`, 0, false},
+		{"invalid string errors", `"not-a-number"`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got PoolSize
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON(%s) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Fatalf("UnmarshalJSON(%s) = %v, want %v", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoolSizeDecodeHookFunc(t *testing.T) {
+	hook := PoolSizeDecodeHookFunc()
+	poolSizeType := reflect.TypeOf(PoolSize(0))
+	stringType := reflect.TypeOf("")
+	intType := reflect.TypeOf(0)
+
+	t.Run("numeric string decodes to PoolSize", func(t *testing.T) {
+		got, err := hook(stringType, poolSizeType, "5")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != PoolSize(5) {
+			t.Fatalf("got %v, want PoolSize(5)", got)
+		}
+	})
+
+	t.Run("empty string decodes to zero", func(t *testing.T) {
+		got, err := hook(stringType, poolSizeType, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != PoolSize(0) {
+			t.Fatalf("got %v, want PoolSize(0)", got)
+		}
+	})
+
+	t.Run("invalid string errors", func(t *testing.T) {
+		if _, err := hook(stringType, poolSizeType, "not-a-number"); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("non-string source passes through unchanged", func(t *testing.T) {
+		got, err := hook(intType, poolSizeType, 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != 5 {
+			t.Fatalf("got %v, want the input unchanged", got)
+		}
+	})
+
+	t.Run("non-PoolSize target passes through unchanged", func(t *testing.T) {
+		got, err := hook(stringType, stringType, "5")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "5" {
+			t.Fatalf("got %v, want the input unchanged", got)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package pgxscan
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// retryableSQLStates are the Postgres SQLSTATE codes a RetryPolicy retries:
+// serialization failures, deadlocks, and connection-level errors. Everything
+// else - including user/application errors and context cancellation - is
+// never retried.
+var retryableSQLStates = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"08006": true, // connection_failure
+	"08003": true, // connection_does_not_exist
+}
+
+func isRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return retryableSQLStates[pgErr.Code]
+	}
+
+	return false
+}
+
+// withRetry runs f under policy, re-running it while its error is retryable.
+// It never retries when ctx already carries a transaction: statements that
+// ran earlier in that transaction can't be safely redone by re-running just
+// f, so InTx only retries at the top level, before any savepoint is nested.
+func withRetry(ctx context.Context, policy RetryPolicy, f func() error) error {
+	if policy.MaxAttempts <= 1 || txFromContext(ctx) != nil {
+		return f()
+	}
+
+	backoff := policy.InitialBackoff
+
+	var err error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		err = f()
+		if err == nil || !isRetryable(err) || attempt == policy.MaxAttempts {
+			return err
+		}
+
+		wait := backoff
+		if policy.Jitter > 0 {
+			wait += time.Duration(rand.Float64() * policy.Jitter * float64(backoff))
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+
+		backoff *= 2
+		if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+
+	return err
+}
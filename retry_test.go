@@ -0,0 +1,121 @@
+package pgxscan
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"context canceled", context.Canceled, false},
+		{"context deadline exceeded", context.DeadlineExceeded, false},
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, true},
+		{"deadlock detected", &pgconn.PgError{Code: "40P01"}, true},
+		{"connection failure", &pgconn.PgError{Code: "08006"}, true},
+		{"connection does not exist", &pgconn.PgError{Code: "08003"}, true},
+		{"unique violation is not retried", &pgconn.PgError{Code: "23505"}, false},
+		{"generic error", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err); got != tt.want {
+				t.Errorf("isRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithRetry_RetriesRetryableErrorsUntilSuccess(t *testing.T) {
+	attempts := 0
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	err := withRetry(context.Background(), policy, func() error {
+		attempts++
+		if attempts < 3 {
+			return &pgconn.PgError{Code: "40001"}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestWithRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	pgErr := &pgconn.PgError{Code: "40001"}
+
+	err := withRetry(context.Background(), policy, func() error {
+		attempts++
+		return pgErr
+	})
+	if !errors.Is(err, pgErr) && err != pgErr {
+		t.Fatalf("expected final error to be %v, got %v", pgErr, err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestWithRetry_DoesNotRetryNonRetryableErrors(t *testing.T) {
+	attempts := 0
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	wantErr := errors.New("boom")
+
+	err := withRetry(context.Background(), policy, func() error {
+		attempts++
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+	if attempts != 1 {
+		t.Fatalf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestWithRetry_SkipsRetryInsideAnActiveTx(t *testing.T) {
+	attempts := 0
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	ctx := contextWithTx(context.Background(), &fakeTx{})
+
+	err := withRetry(ctx, policy, func() error {
+		attempts++
+		return &pgconn.PgError{Code: "40001"}
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected exactly 1 attempt inside a tx, got %d", attempts)
+	}
+}
+
+func TestWithRetry_DisabledByDefault(t *testing.T) {
+	attempts := 0
+
+	err := withRetry(context.Background(), RetryPolicy{}, func() error {
+		attempts++
+		return &pgconn.PgError{Code: "40001"}
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected exactly 1 attempt with the zero-value policy, got %d", attempts)
+	}
+}
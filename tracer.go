@@ -0,0 +1,64 @@
+package pgxscan
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// QueryTracer lets callers observe the queries a Client runs (see WithTracers
+// for which calls are reported): structured logging, OpenTelemetry spans,
+// slow-query logging, and the like can all be built on top of it without
+// modifying Client itself. A tracer only observes; it cannot change or re-run
+// a query (retries are configured with Config.Retry).
+// Implementations must be safe for concurrent use.
+type QueryTracer interface {
+	// TraceQueryStart is called before a query runs, with method set to the
+	// Client method that issued it (e.g. "Get", "InTx"). The returned
+	// context is passed through to the query and to the matching
+	// TraceQueryEnd call.
+	TraceQueryStart(ctx context.Context, method, sql string, args []interface{}) context.Context
+	// TraceQueryEnd is called once the query completes, with err set if it
+	// failed. rowsAffected is 0 for methods that don't report it.
+	TraceQueryEnd(ctx context.Context, method string, err error, rowsAffected int64)
+}
+
+// traceStart runs TraceQueryStart on every configured tracer, threading the
+// context through so tracers can stack (e.g. a span started by one tracer is
+// visible to the next).
+func (c *client) traceStart(ctx context.Context, method, sql string, args []interface{}) context.Context {
+	for _, t := range c.tracers {
+		ctx = t.TraceQueryStart(ctx, method, sql, args)
+	}
+	return ctx
+}
+
+func (c *client) traceEnd(ctx context.Context, method string, err error, rowsAffected int64) {
+	for _, t := range c.tracers {
+		t.TraceQueryEnd(ctx, method, err, rowsAffected)
+	}
+}
+
+// pgxTracerAdapter fans pgx's own low-level query events out to the
+// configured QueryTracers, labeling them with method "pgx" so they're
+// distinguishable from the higher-level Client method events.
+type pgxTracerAdapter struct {
+	tracers []QueryTracer
+}
+
+var _ pgx.QueryTracer = (*pgxTracerAdapter)(nil)
+
+const pgxTraceMethod = "pgx"
+
+func (a *pgxTracerAdapter) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	for _, t := range a.tracers {
+		ctx = t.TraceQueryStart(ctx, pgxTraceMethod, data.SQL, data.Args)
+	}
+	return ctx
+}
+
+func (a *pgxTracerAdapter) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	for _, t := range a.tracers {
+		t.TraceQueryEnd(ctx, pgxTraceMethod, data.Err, data.CommandTag.RowsAffected())
+	}
+}
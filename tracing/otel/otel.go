@@ -0,0 +1,45 @@
+// Package otel provides a pgxscan.QueryTracer that records each query as an
+// OpenTelemetry span.
+package otel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dvsnin/pgxscan"
+)
+
+// Tracer records pgxscan queries as spans on top of Tracer.
+type Tracer struct {
+	Tracer trace.Tracer
+}
+
+var _ pgxscan.QueryTracer = (*Tracer)(nil)
+
+type spanKey struct{}
+
+var ctxSpanKey = spanKey{}
+
+func (t *Tracer) TraceQueryStart(ctx context.Context, method, sql string, args []interface{}) context.Context {
+	ctx, span := t.Tracer.Start(ctx, "pgxscan."+method, trace.WithAttributes(
+		attribute.String("db.statement", sql),
+	))
+	return context.WithValue(ctx, ctxSpanKey, span)
+}
+
+func (t *Tracer) TraceQueryEnd(ctx context.Context, method string, err error, rowsAffected int64) {
+	span, ok := ctx.Value(ctxSpanKey).(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("db.rows_affected", rowsAffected))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,67 @@
+// Package tracing provides ready-made pgxscan.QueryTracer implementations.
+package tracing
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dvsnin/pgxscan"
+)
+
+// SlogTracer logs every query run by a pgxscan.Client through Logger. If
+// SlowThreshold is positive, queries taking at least that long are logged at
+// slog.LevelWarn regardless of Level.
+type SlogTracer struct {
+	Logger        *slog.Logger
+	Level         slog.Level
+	SlowThreshold time.Duration
+}
+
+var _ pgxscan.QueryTracer = (*SlogTracer)(nil)
+
+type slogStartKey struct{}
+
+var ctxStartKey = slogStartKey{}
+
+// slogTraceState is stashed on the context between TraceQueryStart and
+// TraceQueryEnd so the latter can log the SQL text alongside the duration.
+type slogTraceState struct {
+	start time.Time
+	sql   string
+}
+
+func (t *SlogTracer) TraceQueryStart(ctx context.Context, method, sql string, args []interface{}) context.Context {
+	return context.WithValue(ctx, ctxStartKey, slogTraceState{start: time.Now(), sql: sql})
+}
+
+func (t *SlogTracer) TraceQueryEnd(ctx context.Context, method string, err error, rowsAffected int64) {
+	var duration time.Duration
+	var sql string
+	if state, ok := ctx.Value(ctxStartKey).(slogTraceState); ok {
+		duration = time.Since(state.start)
+		sql = state.sql
+	}
+
+	level := t.Level
+	if t.SlowThreshold > 0 && duration >= t.SlowThreshold {
+		level = slog.LevelWarn
+	}
+
+	logger := t.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("sql", sql),
+		slog.Duration("duration", duration),
+		slog.Int64("rows_affected", rowsAffected),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+
+	logger.LogAttrs(ctx, level, "pgxscan query", attrs...)
+}
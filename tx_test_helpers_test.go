@@ -0,0 +1,44 @@
+package pgxscan
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// fakeTx is a minimal pgx.Tx stub used to test InTx's savepoint nesting and
+// retry gating without a live Postgres connection. Embedding the (nil) pgx.Tx
+// interface satisfies methods this package doesn't exercise; calling one of
+// those would panic, which is the desired failure mode for an unexpected call.
+type fakeTx struct {
+	pgx.Tx
+
+	beginErr    error
+	commitErr   error
+	rollbackErr error
+
+	child *fakeTx
+
+	committed  bool
+	rolledBack bool
+}
+
+func (f *fakeTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	if f.beginErr != nil {
+		return nil, f.beginErr
+	}
+	if f.child == nil {
+		f.child = &fakeTx{}
+	}
+	return f.child, nil
+}
+
+func (f *fakeTx) Commit(ctx context.Context) error {
+	f.committed = true
+	return f.commitErr
+}
+
+func (f *fakeTx) Rollback(ctx context.Context) error {
+	f.rolledBack = true
+	return f.rollbackErr
+}